@@ -7,9 +7,9 @@ import (
 
 func main() {
 	bytes, _ := os.ReadFile("test.lang")
-	tokens := lexer.Tokenize(string(bytes))
+	r := lexer.NewStack(lexer.NewLexer("test.lang", string(bytes)))
 
-	for _, token := range tokens {
-		token.Debug()
+	for tok := r.Next(); tok.Kind != lexer.TokenKindEOF; tok = r.Next() {
+		tok.Debug()
 	}
 }
@@ -0,0 +1,23 @@
+package lexer
+
+import "fmt"
+
+// Error is a lexical error encountered while scanning, e.g. an
+// unterminated string or an unknown escape sequence. It carries the
+// source position so callers can report it the same way as any other
+// compiler diagnostic.
+type Error struct {
+	Pos Position
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// errorToken turns an Error into the TokenKindError token the lexer
+// emits in place of the literal it failed to scan.
+func errorToken(pos Position, format string, args ...any) Token {
+	err := &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+	return Token{Kind: TokenKindError, Value: err.Error(), Pos: pos}
+}
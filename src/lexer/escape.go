@@ -0,0 +1,130 @@
+package lexer
+
+// simpleEscapes maps the character following a backslash to the byte it
+// decodes to, for every escape that isn't the \xNN hex form.
+var simpleEscapes = map[byte]byte{
+	'a':  '\a',
+	'b':  '\b',
+	'f':  '\f',
+	'n':  '\n',
+	'r':  '\r',
+	't':  '\t',
+	'v':  '\v',
+	'\'': '\'',
+	'"':  '"',
+	'\\': '\\',
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func hexValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'f':
+		return int(c-'a') + 10
+	default:
+		return int(c-'A') + 10
+	}
+}
+
+// readEscape consumes the backslash already peeked and the escape it
+// introduces, returning the decoded byte. ok is false if the escape is
+// unterminated or not recognized, in which case errTok is the token to
+// return in its place.
+func (l *Lexer) readEscape() (decoded byte, ok bool, errTok Token) {
+	pos := l.here()
+	l.advance() // consume '\'
+
+	if l.eof() {
+		return 0, false, errorToken(pos, "unterminated escape sequence")
+	}
+
+	c := l.advance()
+	if c == 'x' {
+		if !isHexDigit(l.peek()) || !isHexDigit(l.peekAt(1)) {
+			return 0, false, errorToken(pos, "invalid hex escape, expected \\xNN")
+		}
+		hi := hexValue(l.advance())
+		lo := hexValue(l.advance())
+		return byte(hi*16 + lo), true, Token{}
+	}
+
+	decoded, known := simpleEscapes[c]
+	if !known {
+		return 0, false, errorToken(pos, "unknown escape sequence \\%c", c)
+	}
+	return decoded, true, Token{}
+}
+
+// lexString scans a "..." literal starting at the current '"', decoding
+// escapes as it goes.
+func (l *Lexer) lexString() (Token, bool) {
+	if l.peek() != '"' {
+		return Token{}, false
+	}
+	pos := l.here()
+	l.advance() // opening quote
+
+	var value []byte
+	for {
+		if l.eof() || l.peek() == '\n' {
+			return errorToken(pos, "unterminated string literal"), true
+		}
+		if l.peek() == '"' {
+			l.advance()
+			return Token{Kind: TokenKindString, Value: string(value), Pos: pos}, true
+		}
+		if l.peek() == '\\' {
+			decoded, ok, errTok := l.readEscape()
+			if !ok {
+				return errTok, true
+			}
+			value = append(value, decoded)
+			continue
+		}
+		value = append(value, l.advance())
+	}
+}
+
+// lexRune scans a 'x' literal starting at the current single quote.
+func (l *Lexer) lexRune() (Token, bool) {
+	if l.peek() != '\'' {
+		return Token{}, false
+	}
+	pos := l.here()
+	l.advance() // opening quote
+
+	if l.eof() || l.peek() == '\n' {
+		return errorToken(pos, "unterminated rune literal"), true
+	}
+
+	var value rune
+	if l.peek() == '\\' {
+		decoded, ok, errTok := l.readEscape()
+		if !ok {
+			return errTok, true
+		}
+		value = rune(decoded)
+	} else {
+		value = l.advanceRune()
+	}
+
+	if l.eof() || l.peek() != '\'' {
+		// Either unterminated, or there was more than one character
+		// before the closing quote.
+		for !l.eof() && l.peek() != '\'' && l.peek() != '\n' {
+			l.advance()
+		}
+		if l.peek() == '\'' {
+			l.advance()
+			return errorToken(pos, "rune literal contains more than one character"), true
+		}
+		return errorToken(pos, "unterminated rune literal"), true
+	}
+	l.advance() // closing quote
+
+	return Token{Kind: TokenKindRune, Value: string(value), Rune: value, Pos: pos}, true
+}
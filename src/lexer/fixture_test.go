@@ -0,0 +1,126 @@
+package lexer
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// update regenerates the golden token dumps under testdata/ from the
+// current lexer output. Run with:
+//
+//	go test ./src/lexer -run TestFixtures -update
+var update = flag.Bool("update", false, "update golden token fixtures")
+
+// fixtures names every testdata/<name>.lang file exercised by TestFixtures,
+// each checked against its golden testdata/<name>.golden dump.
+var fixtures = []string{"all", "strings", "numbers", "keywords", "operators", "comments"}
+
+func TestFixtures(t *testing.T) {
+	for _, name := range fixtures {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			langPath := filepath.Join("testdata", name+".lang")
+			goldenPath := filepath.Join("testdata", name+".golden")
+
+			src, err := os.ReadFile(langPath)
+			if err != nil {
+				t.Fatalf("reading fixture: %v", err)
+			}
+			got := Tokenize(string(src))
+
+			if *update {
+				if err := writeGolden(goldenPath, got); err != nil {
+					t.Fatalf("writing golden: %v", err)
+				}
+				return
+			}
+
+			want, err := readGolden(goldenPath)
+			if err != nil {
+				t.Fatalf("reading golden (run with -update to generate it): %v", err)
+			}
+
+			if len(got) != len(want) {
+				t.Fatalf("got %d tokens, want %d\ngot:  %v\nwant: %v", len(got), len(want), got, want)
+			}
+			for i := range want {
+				if !got[i].Equals(want[i], false) {
+					t.Errorf("token %d:\n got  %+v\n want %+v", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+// writeGolden dumps tokens to path, one per line, as:
+//
+//	KIND\tvalue(quoted)\trune\tline\tcolumn
+func writeGolden(path string, tokens []Token) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, tok := range tokens {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", tok.Kind, strconv.Quote(tok.Value), tok.Rune, tok.Pos.Line, tok.Pos.Column)
+	}
+	return w.Flush()
+}
+
+// readGolden parses a dump written by writeGolden back into Tokens. The
+// returned tokens' Pos.File is left empty since line/column, not the
+// file name, is what a golden fixture pins down.
+func readGolden(path string) ([]Token, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []Token
+	for lineNo, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 5)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("golden line %d: malformed %q", lineNo+1, line)
+		}
+
+		kind, ok := tokenKindNames[fields[0]]
+		if !ok {
+			return nil, fmt.Errorf("golden line %d: unknown token kind %q", lineNo+1, fields[0])
+		}
+		value, err := strconv.Unquote(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("golden line %d: %v", lineNo+1, err)
+		}
+		r, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("golden line %d: %v", lineNo+1, err)
+		}
+		tokLine, err := strconv.Atoi(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("golden line %d: %v", lineNo+1, err)
+		}
+		col, err := strconv.Atoi(fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("golden line %d: %v", lineNo+1, err)
+		}
+
+		tokens = append(tokens, Token{
+			Kind:  kind,
+			Value: value,
+			Rune:  rune(r),
+			Pos:   Position{Line: tokLine, Column: col},
+		})
+	}
+	return tokens, nil
+}
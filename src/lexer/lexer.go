@@ -0,0 +1,232 @@
+package lexer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// operators are matched longest-first so that e.g. "==" is not split into
+// two "=" tokens.
+var operators = []string{
+	"==", "!=", "<=", ">=", "&&", "||", "+=", "-=", "*=", "/=",
+	"+", "-", "*", "/", "%", "=", "<", ">", "!", "&", "|",
+}
+
+var punctuation = "(){}[],;:."
+
+// TokenReader is anything that produces a stream of Tokens, one at a time.
+// The last Token returned before the source is exhausted has Kind
+// TokenKindEOF; every call after that continues to return EOF tokens.
+type TokenReader interface {
+	Next() Token
+}
+
+// Lexer scans a single source string into Tokens on demand. It implements
+// TokenReader and is the leaf reader that a Stack pushes file or macro
+// sources onto.
+type Lexer struct {
+	file string
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+// NewLexer creates a Lexer over src, reporting positions under the given
+// file name (used for Token.Pos and in error messages).
+func NewLexer(file, src string) *Lexer {
+	return &Lexer{file: file, src: src, line: 1, col: 1}
+}
+
+func (l *Lexer) eof() bool {
+	return l.pos >= len(l.src)
+}
+
+func (l *Lexer) peek() byte {
+	if l.eof() {
+		return 0
+	}
+	return l.src[l.pos]
+}
+
+func (l *Lexer) peekAt(offset int) byte {
+	if l.pos+offset >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos+offset]
+}
+
+func (l *Lexer) advance() byte {
+	c := l.src[l.pos]
+	l.pos++
+	if c == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return c
+}
+
+// advanceRune consumes one full UTF-8 rune, however many bytes wide, and
+// returns it. Unlike advance, which only ever moves one byte, this is
+// what callers must use wherever a single *character* is expected, e.g.
+// the body of a rune literal.
+func (l *Lexer) advanceRune() rune {
+	r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+	for i := 0; i < size; i++ {
+		l.advance()
+	}
+	return r
+}
+
+func (l *Lexer) here() Position {
+	return Position{File: l.file, Line: l.line, Column: l.col}
+}
+
+// Next returns the next Token in the source, or an EOF token once the
+// source is exhausted.
+func (l *Lexer) Next() Token {
+	l.skipWhitespace()
+
+	if l.eof() {
+		return Token{Kind: TokenKindEOF, Pos: l.here()}
+	}
+
+	if tok, ok := l.lexComment(); ok {
+		return tok
+	}
+	if tok, ok := l.lexString(); ok {
+		return tok
+	}
+	if tok, ok := l.lexRune(); ok {
+		return tok
+	}
+	if tok, ok := l.lexNumber(); ok {
+		return tok
+	}
+	if tok, ok := l.lexIdent(); ok {
+		return tok
+	}
+	if tok, ok := l.lexOperator(); ok {
+		return tok
+	}
+	if tok, ok := l.lexPunct(); ok {
+		return tok
+	}
+
+	// Unrecognized byte: emit it as a single-character operator token so
+	// that lexing never stalls, and move past it.
+	pos := l.here()
+	c := l.advance()
+	return Token{Kind: TokenKindOperator, Value: string(c), Pos: pos}
+}
+
+func (l *Lexer) skipWhitespace() {
+	for !l.eof() {
+		switch l.peek() {
+		case ' ', '\t', '\r', '\n':
+			l.advance()
+		default:
+			return
+		}
+	}
+}
+
+func (l *Lexer) lexComment() (Token, bool) {
+	if l.peek() != '/' {
+		return Token{}, false
+	}
+	pos := l.here()
+	switch l.peekAt(1) {
+	case '/':
+		start := l.pos
+		for !l.eof() && l.peek() != '\n' {
+			l.advance()
+		}
+		return Token{Kind: TokenKindComment, Value: l.src[start:l.pos], Pos: pos}, true
+	case '*':
+		start := l.pos
+		l.advance()
+		l.advance()
+		for !l.eof() && !(l.peek() == '*' && l.peekAt(1) == '/') {
+			l.advance()
+		}
+		if !l.eof() {
+			l.advance()
+			l.advance()
+		}
+		return Token{Kind: TokenKindComment, Value: l.src[start:l.pos], Pos: pos}, true
+	default:
+		return Token{}, false
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+func (l *Lexer) lexNumber() (Token, bool) {
+	if !isDigit(l.peek()) {
+		return Token{}, false
+	}
+	pos := l.here()
+	start := l.pos
+	for !l.eof() && isDigit(l.peek()) {
+		l.advance()
+	}
+	if l.peek() == '.' && isDigit(l.peekAt(1)) {
+		l.advance()
+		for !l.eof() && isDigit(l.peek()) {
+			l.advance()
+		}
+	}
+	return Token{Kind: TokenKindNumber, Value: l.src[start:l.pos], Pos: pos}, true
+}
+
+func (l *Lexer) lexIdent() (Token, bool) {
+	if !isIdentStart(l.peek()) {
+		return Token{}, false
+	}
+	pos := l.here()
+	start := l.pos
+	for !l.eof() && isIdentPart(l.peek()) {
+		l.advance()
+	}
+	value := l.src[start:l.pos]
+	kind := TokenKindIdent
+	if keywords[value] {
+		kind = TokenKindKeyword
+	}
+	return Token{Kind: kind, Value: value, Pos: pos}, true
+}
+
+func (l *Lexer) lexOperator() (Token, bool) {
+	pos := l.here()
+	for _, op := range operators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			for range op {
+				l.advance()
+			}
+			return Token{Kind: TokenKindOperator, Value: op, Pos: pos}, true
+		}
+	}
+	return Token{}, false
+}
+
+func (l *Lexer) lexPunct() (Token, bool) {
+	if !strings.ContainsRune(punctuation, rune(l.peek())) {
+		return Token{}, false
+	}
+	pos := l.here()
+	c := l.advance()
+	return Token{Kind: TokenKindPunct, Value: string(c), Pos: pos}, true
+}
@@ -0,0 +1,69 @@
+package lexer
+
+import (
+	"os"
+	"testing"
+)
+
+func TestStringAndRuneLiterals(t *testing.T) {
+	src, err := os.ReadFile("testdata/strings.lang")
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+
+	tokens := Tokenize(string(src))
+
+	var strings, runes []Token
+	for _, tok := range tokens {
+		switch tok.Kind {
+		case TokenKindString:
+			strings = append(strings, tok)
+		case TokenKindRune:
+			runes = append(runes, tok)
+		case TokenKindError:
+			t.Fatalf("unexpected lexer error: %s", tok.Value)
+		}
+	}
+
+	wantStrings := []string{"hello\nworld", "\x41\x42"}
+	if len(strings) != len(wantStrings) {
+		t.Fatalf("got %d string tokens, want %d", len(strings), len(wantStrings))
+	}
+	for i, want := range wantStrings {
+		if strings[i].Value != want {
+			t.Errorf("string %d = %q, want %q", i, strings[i].Value, want)
+		}
+	}
+
+	wantRunes := []rune{'x', '\t', 'é'}
+	if len(runes) != len(wantRunes) {
+		t.Fatalf("got %d rune tokens, want %d", len(runes), len(wantRunes))
+	}
+	for i, want := range wantRunes {
+		if runes[i].Rune != want {
+			t.Errorf("rune %d = %q, want %q", i, runes[i].Rune, want)
+		}
+	}
+}
+
+func TestStringLexErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		src  string
+	}{
+		{"unterminated string", `"abc`},
+		{"unknown escape", `"\q"`},
+		{"unterminated rune", `'a`},
+		{"multi-char rune", `'ab'`},
+		{"multi-codepoint rune", `'éé'`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tokens := Tokenize(c.src)
+			if len(tokens) == 0 || tokens[0].Kind != TokenKindError {
+				t.Fatalf("Tokenize(%q) = %+v, want a leading TokenKindError", c.src, tokens)
+			}
+		})
+	}
+}
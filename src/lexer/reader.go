@@ -0,0 +1,143 @@
+package lexer
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// DefaultByteLimit bounds how much input TokenizeReader will read when no
+// explicit limit is given, so a caller can hand it an arbitrarily large
+// or unbounded stream without risking unbounded memory use.
+const DefaultByteLimit = 8 << 20 // 8 MiB
+
+// readChunkSize is how much is read from the source Reader at a time.
+const readChunkSize = 64 << 10 // 64 KiB
+
+// TokenizeLimit tokenizes at most the first maxBytes of src. A token
+// whose span would cross the maxBytes boundary is dropped rather than
+// returned truncated, exactly as if the cap had landed there in a
+// streaming read.
+func TokenizeLimit(src string, maxBytes int) []Token {
+	if maxBytes < 0 || maxBytes > len(src) {
+		maxBytes = len(src)
+	}
+
+	l := NewLexer("", src)
+	var tokens []Token
+	for {
+		if l.pos >= maxBytes {
+			break
+		}
+		tok := l.Next()
+		if tok.Kind == TokenKindEOF {
+			break
+		}
+		if l.pos > maxBytes {
+			// The token we just scanned straddles the cap; had this been
+			// read in chunks it would have arrived only half-formed, so
+			// discard it instead of returning it truncated.
+			break
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+// TokenizeReader reads r in bounded chunks and tokenizes up to
+// DefaultByteLimit bytes of it, so a caller can safely point it at a
+// large or even unbounded stream. See TokenizeReaderLimit to pick a
+// different cap.
+func TokenizeReader(r io.Reader) []Token {
+	return TokenizeReaderLimit(r, DefaultByteLimit)
+}
+
+// TokenizeReaderLimit is TokenizeReader with an explicit byte cap.
+func TokenizeReaderLimit(r io.Reader, maxBytes int) []Token {
+	src, truncated, spill := readLimitedUTF8(r, maxBytes)
+	if !truncated {
+		return Tokenize(src)
+	}
+	// The stream had more to give. Rather than pessimistically dropping
+	// the final token on the assumption it's a half-read prefix, pull in
+	// a bounded amount of lookahead past the cap (starting with whatever
+	// spilled past the cap while detecting truncation, so no byte of the
+	// stream is lost) and let TokenizeLimit's precise straddle check
+	// decide whether that token actually crosses maxBytes.
+	lookahead, _, _ := readLimitedUTF8(r, readChunkSize)
+	return TokenizeLimit(src+string(spill)+lookahead, maxBytes)
+}
+
+// readLimitedUTF8 reads up to maxBytes from r in readChunkSize chunks and
+// returns what it read, plus whether the cap was hit before r was
+// exhausted. It never leaves a chunk boundary in the middle of a
+// multi-byte UTF-8 rune: trailing incomplete bytes from one chunk are
+// carried over and prefixed onto the next read.
+//
+// When truncated is true, spill holds any bytes read from r past the
+// maxBytes cut (including any single byte read just to detect that the
+// cap was truncating rather than landing on the stream's actual end).
+// Callers that need to look past the cap must prepend spill to whatever
+// they read next, or that data is lost for good.
+func readLimitedUTF8(r io.Reader, maxBytes int) (src string, truncated bool, spill []byte) {
+	var out []byte
+	var carry []byte
+
+	for len(out) < maxBytes {
+		chunk := make([]byte, readChunkSize)
+		n, err := r.Read(chunk)
+		chunk = chunk[:n]
+
+		buf := append(carry, chunk...)
+		carry = nil
+
+		if err == nil {
+			// Hold back a trailing incomplete rune so it can be
+			// completed by the next chunk instead of being split.
+			buf, carry = splitTrailingIncompleteRune(buf)
+		}
+
+		if len(out)+len(buf) > maxBytes {
+			cut := maxBytes - len(out)
+			overflow := append([]byte(nil), buf[cut:]...)
+			overflow = append(overflow, carry...)
+			out = append(out, buf[:cut]...)
+			return string(out), true, overflow
+		}
+		out = append(out, buf...)
+
+		if err != nil {
+			return string(out), false, nil
+		}
+	}
+	if len(carry) > 0 {
+		// A trailing incomplete rune is still waiting on more bytes that
+		// we never asked for: the real source keeps going past the cap.
+		return string(out), true, carry
+	}
+	// We filled out to exactly maxBytes without r reporting EOF. Probe
+	// for one more byte to tell a genuine cap-hit from a source that
+	// simply ended exactly on the boundary, without losing it if so.
+	var probe [1]byte
+	n, _ := r.Read(probe[:])
+	if n > 0 {
+		return string(out), true, probe[:n]
+	}
+	return string(out), false, nil
+}
+
+// splitTrailingIncompleteRune separates buf into the leading bytes that
+// form complete runes and a trailing incomplete multi-byte sequence, if
+// any. If buf ends on a rune boundary, incomplete is empty.
+func splitTrailingIncompleteRune(buf []byte) (complete, incomplete []byte) {
+	// Look back at most one UTF-8 sequence (4 bytes).
+	for back := 1; back <= 4 && back <= len(buf); back++ {
+		start := len(buf) - back
+		if utf8.RuneStart(buf[start]) {
+			if !utf8.FullRune(buf[start:]) {
+				return buf[:start], buf[start:]
+			}
+			return buf, nil
+		}
+	}
+	return buf, nil
+}
@@ -0,0 +1,113 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenizeLimitDropsStraddlingToken(t *testing.T) {
+	src := "let abcdefgh = 1;"
+	// Cut mid-identifier ("abcdefgh"); the partial identifier must not
+	// appear in the result.
+	cut := strings.Index(src, "abcdefgh") + 3
+
+	tokens := TokenizeLimit(src, cut)
+	for _, tok := range tokens {
+		if strings.HasPrefix(tok.Value, "abc") {
+			t.Fatalf("got straddling token %+v, want it dropped", tok)
+		}
+	}
+	if len(tokens) == 0 || tokens[len(tokens)-1].Value != "let" {
+		t.Fatalf("tokens = %+v, want just the leading keyword", tokens)
+	}
+}
+
+func TestTokenizeLimitNoTruncationNeeded(t *testing.T) {
+	src := "let x = 1;"
+	got := TokenizeLimit(src, len(src)+100)
+	want := Tokenize(src)
+	if len(got) != len(want) {
+		t.Fatalf("TokenizeLimit with a generous cap = %d tokens, want %d", len(got), len(want))
+	}
+}
+
+func TestTokenizeReaderMatchesTokenize(t *testing.T) {
+	src := `let greeting = "hello\nworld"; let c = 'x';`
+	got := TokenizeReader(strings.NewReader(src))
+	want := Tokenize(src)
+
+	if len(got) != len(want) {
+		t.Fatalf("TokenizeReader produced %d tokens, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Kind != want[i].Kind || got[i].Value != want[i].Value {
+			t.Errorf("token %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTokenizeReaderLimitAcrossChunkBoundary(t *testing.T) {
+	// "café" has a 2-byte UTF-8 rune ('é'); pad the input so that the
+	// first byte of 'é' is the last byte of the first read and the second
+	// byte arrives in the next one.
+	src := strings.Repeat("x", readChunkSize-4) + "café"
+	got := TokenizeReaderLimit(strings.NewReader(src), len(src))
+	want := Tokenize(src)
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d tokens, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Value != want[i].Value {
+			t.Errorf("token %d = %q, want %q", i, got[i].Value, want[i].Value)
+		}
+	}
+}
+
+func TestTokenizeReaderLimitKeepsTokenAtExactBoundary(t *testing.T) {
+	first := "let x = 1;"
+	src := first + " let y = 2;"
+
+	got := TokenizeReaderLimit(strings.NewReader(src), len(first))
+
+	want := Tokenize(first)
+	if len(got) != len(want) {
+		t.Fatalf("got %d tokens, want %d (a complete trailing token was dropped): %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].Kind != want[i].Kind || got[i].Value != want[i].Value {
+			t.Errorf("token %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTokenizeReaderLimitAtChunkSizeMultipleKeepsBoundaryToken(t *testing.T) {
+	// maxBytes lands exactly on a readChunkSize boundary, right after a
+	// complete identifier token and before more input: the probe byte
+	// used to detect truncation must not be lost from the stream.
+	first := strings.Repeat("b", readChunkSize)
+	src := first + " cccc"
+
+	got := TokenizeReaderLimit(strings.NewReader(src), readChunkSize)
+
+	want := Tokenize(first)
+	if len(got) != len(want) {
+		t.Fatalf("got %d tokens, want %d (boundary token lost): %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].Kind != want[i].Kind || got[i].Value != want[i].Value {
+			t.Errorf("token %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTokenizeReaderLimitStopsAtCap(t *testing.T) {
+	src := strings.Repeat("let x = 1; ", 1000)
+	got := TokenizeReaderLimit(strings.NewReader(src), 50)
+
+	for _, tok := range got {
+		if tok.Pos.Column > 50 {
+			t.Fatalf("token %+v came from beyond the byte cap", tok)
+		}
+	}
+}
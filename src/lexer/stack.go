@@ -0,0 +1,45 @@
+package lexer
+
+// Stack is a TokenReader backed by a stack of TokenReaders. The reader on
+// top is consumed first; when it reaches EOF, Stack pops it and resumes
+// the reader underneath, so the caller sees one seamless token stream
+// across file includes and macro expansions while each Token still
+// carries the file/line info of whichever reader produced it.
+//
+// This mirrors the lexer stack used by Go's cmd/asm/internal/lex: pushing
+// an included file's reader (or a macro body's reader) on top makes the
+// parser-facing stream transparently splice it in.
+type Stack struct {
+	readers []TokenReader
+}
+
+// NewStack creates a Stack with r as its initial, bottom-most reader.
+func NewStack(r TokenReader) *Stack {
+	return &Stack{readers: []TokenReader{r}}
+}
+
+// Push makes r the new top of the stack; its tokens are returned by Next
+// until it hits EOF, at which point the previous top resumes.
+func (s *Stack) Push(r TokenReader) {
+	s.readers = append(s.readers, r)
+}
+
+// Next returns the next token from the top reader, popping exhausted
+// readers as needed. Once every reader on the stack is exhausted it
+// returns an EOF token.
+func (s *Stack) Next() Token {
+	for len(s.readers) > 0 {
+		top := s.readers[len(s.readers)-1]
+		tok := top.Next()
+		if tok.Kind != TokenKindEOF {
+			return tok
+		}
+		if len(s.readers) == 1 {
+			// Bottom of the stack: report its own EOF rather than
+			// popping it away, so repeated calls keep returning EOF.
+			return tok
+		}
+		s.readers = s.readers[:len(s.readers)-1]
+	}
+	return Token{Kind: TokenKindEOF}
+}
@@ -0,0 +1,19 @@
+package lexer
+
+// TokenAll drains r until EOF and returns the tokens seen, not including
+// the terminal EOF token. It exists so callers that want the old
+// slice-based API can sit on top of any TokenReader, including a Stack.
+func TokenAll(r TokenReader) []Token {
+	var tokens []Token
+	for tok := r.Next(); tok.Kind != TokenKindEOF; tok = r.Next() {
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+// Tokenize lexes src in one shot and returns its tokens as a slice. It is
+// a thin convenience wrapper around Lexer/Stack/TokenAll for callers that
+// don't need streaming or includes.
+func Tokenize(src string) []Token {
+	return TokenAll(NewStack(NewLexer("", src)))
+}